@@ -0,0 +1,42 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/llms/anthropic"
+)
+
+// anthropicBackend talks to Claude directly through Anthropic's API,
+// instead of through Bedrock.
+type anthropicBackend struct {
+	llm   *anthropic.LLM
+	model string
+	cfg   ProviderConfig
+}
+
+func newAnthropicBackend(cfg ProviderConfig) (Backend, error) {
+	opts := []anthropic.Option{}
+	if cfg.Model != "" {
+		opts = append(opts, anthropic.WithModel(cfg.Model))
+	}
+
+	llm, err := anthropic.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &anthropicBackend{llm: llm, model: cfg.Model, cfg: cfg}, nil
+}
+
+func (b *anthropicBackend) Name() Name    { return Anthropic }
+func (b *anthropicBackend) Model() string { return b.model }
+
+func (b *anthropicBackend) Call(ctx context.Context, prompt string, opts CallOptions) (string, error) {
+	return b.llm.Call(ctx, prompt, callOptions(b.cfg, opts)...)
+}
+
+func (b *anthropicBackend) Stream(ctx context.Context, prompt string, opts CallOptions, chunkCh chan<- string) error {
+	streamOpts := append(callOptions(b.cfg, opts), streamingOption(chunkCh))
+	_, err := b.llm.Call(ctx, prompt, streamOpts...)
+	return err
+}
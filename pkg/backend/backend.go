@@ -0,0 +1,69 @@
+// Package backend abstracts over the LLM providers kakapo can talk to, so
+// the TUI doesn't need to know whether it's driving Bedrock, OpenAI,
+// Anthropic's API, or a local Ollama model.
+package backend
+
+import "context"
+
+// Name identifies a backend implementation.
+type Name string
+
+const (
+	Bedrock   Name = "bedrock"
+	OpenAI    Name = "openai"
+	Anthropic Name = "anthropic"
+	Ollama    Name = "ollama"
+)
+
+// Names lists every backend in the order the "change model" command cycles
+// through them.
+var Names = []Name{Bedrock, OpenAI, Anthropic, Ollama}
+
+// CallOptions overrides a backend's configured defaults for a single call.
+// A zero value for any field means "use the backend's configured default".
+type CallOptions struct {
+	Temperature float64
+	MaxTokens   int
+	TopK        int
+}
+
+// Backend is an LLM provider kakapo can send prompts to.
+type Backend interface {
+	// Name identifies which provider this backend talks to.
+	Name() Name
+
+	// Model is the model identifier this backend is currently configured to use.
+	Model() string
+
+	// Call sends prompt and returns the complete response.
+	Call(ctx context.Context, prompt string, opts CallOptions) (string, error)
+
+	// Stream sends prompt and pushes response fragments to chunkCh as they
+	// arrive, closing chunkCh is the caller's responsibility, not Stream's.
+	Stream(ctx context.Context, prompt string, opts CallOptions, chunkCh chan<- string) error
+}
+
+// New builds the Backend for name using its section of cfg.
+func New(name Name, cfg Config) (Backend, error) {
+	switch name {
+	case Bedrock:
+		return newBedrockBackend(cfg.Bedrock)
+	case OpenAI:
+		return newOpenAIBackend(cfg.OpenAI)
+	case Anthropic:
+		return newAnthropicBackend(cfg.Anthropic)
+	case Ollama:
+		return newOllamaBackend(cfg.Ollama)
+	default:
+		return nil, UnknownBackendError{Name: name}
+	}
+}
+
+// UnknownBackendError is returned by New for a Name outside Names.
+type UnknownBackendError struct {
+	Name Name
+}
+
+func (e UnknownBackendError) Error() string {
+	return "backend: unknown backend " + string(e.Name)
+}
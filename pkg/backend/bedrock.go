@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/build-on-aws/langchaingo-amazon-bedrock-llm/claude"
+)
+
+// bedrockBackend talks to Claude on Amazon Bedrock.
+type bedrockBackend struct {
+	llm   *claude.LLM
+	model string
+	cfg   ProviderConfig
+}
+
+func newBedrockBackend(cfg ProviderConfig) (Backend, error) {
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	llm, err := claude.New(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bedrockBackend{llm: llm, model: cfg.Model, cfg: cfg}, nil
+}
+
+func (b *bedrockBackend) Name() Name    { return Bedrock }
+func (b *bedrockBackend) Model() string { return b.model }
+
+func (b *bedrockBackend) Call(ctx context.Context, prompt string, opts CallOptions) (string, error) {
+	return b.llm.Call(ctx, prompt, callOptions(b.cfg, opts)...)
+}
+
+func (b *bedrockBackend) Stream(ctx context.Context, prompt string, opts CallOptions, chunkCh chan<- string) error {
+	streamOpts := append(callOptions(b.cfg, opts), streamingOption(chunkCh))
+	_, err := b.llm.Call(ctx, prompt, streamOpts...)
+	return err
+}
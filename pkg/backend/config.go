@@ -0,0 +1,69 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds per-backend settings loaded from ~/.config/kakapo/config.yaml.
+type Config struct {
+	Backend   Name           `yaml:"backend"`
+	Bedrock   ProviderConfig `yaml:"bedrock"`
+	OpenAI    ProviderConfig `yaml:"openai"`
+	Anthropic ProviderConfig `yaml:"anthropic"`
+	Ollama    ProviderConfig `yaml:"ollama"`
+}
+
+// ProviderConfig is one backend's section of the config file. Region only
+// applies to Bedrock; the rest are common generation parameters.
+type ProviderConfig struct {
+	Model       string  `yaml:"model"`
+	Region      string  `yaml:"region"`
+	Temperature float64 `yaml:"temperature"`
+	MaxTokens   int     `yaml:"max_tokens"`
+	TopK        int     `yaml:"top_k"`
+}
+
+// defaultConfig matches kakapo's previous hardcoded Bedrock Claude setup.
+func defaultConfig() Config {
+	return Config{
+		Backend: Bedrock,
+		Bedrock: ProviderConfig{
+			Region:      "us-east-1",
+			Temperature: 0.5,
+			MaxTokens:   2048,
+			TopK:        250,
+		},
+	}
+}
+
+// LoadConfig reads the YAML config at path, falling back to defaultConfig
+// when the file doesn't exist.
+func LoadConfig(path string) (Config, error) {
+	cfg := defaultConfig()
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("backend: read config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return Config{}, fmt.Errorf("backend: parse config: %w", err)
+	}
+	return cfg, nil
+}
+
+// DefaultConfigPath returns the path kakapo reads its config from by default.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("backend: resolve config dir: %w", err)
+	}
+	return filepath.Join(dir, "kakapo", "config.yaml"), nil
+}
@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/llms/ollama"
+)
+
+// ollamaBackend talks to a local Ollama server.
+type ollamaBackend struct {
+	llm   *ollama.LLM
+	model string
+	cfg   ProviderConfig
+}
+
+func newOllamaBackend(cfg ProviderConfig) (Backend, error) {
+	model := cfg.Model
+	if model == "" {
+		model = "llama3"
+	}
+
+	llm, err := ollama.New(ollama.WithModel(model))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ollamaBackend{llm: llm, model: model, cfg: cfg}, nil
+}
+
+func (b *ollamaBackend) Name() Name    { return Ollama }
+func (b *ollamaBackend) Model() string { return b.model }
+
+func (b *ollamaBackend) Call(ctx context.Context, prompt string, opts CallOptions) (string, error) {
+	return b.llm.Call(ctx, prompt, callOptions(b.cfg, opts)...)
+}
+
+func (b *ollamaBackend) Stream(ctx context.Context, prompt string, opts CallOptions, chunkCh chan<- string) error {
+	streamOpts := append(callOptions(b.cfg, opts), streamingOption(chunkCh))
+	_, err := b.llm.Call(ctx, prompt, streamOpts...)
+	return err
+}
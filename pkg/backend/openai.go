@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+// openAIBackend talks to the OpenAI API.
+type openAIBackend struct {
+	llm   *openai.LLM
+	model string
+	cfg   ProviderConfig
+}
+
+func newOpenAIBackend(cfg ProviderConfig) (Backend, error) {
+	opts := []openai.Option{}
+	if cfg.Model != "" {
+		opts = append(opts, openai.WithModel(cfg.Model))
+	}
+
+	llm, err := openai.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &openAIBackend{llm: llm, model: cfg.Model, cfg: cfg}, nil
+}
+
+func (b *openAIBackend) Name() Name    { return OpenAI }
+func (b *openAIBackend) Model() string { return b.model }
+
+func (b *openAIBackend) Call(ctx context.Context, prompt string, opts CallOptions) (string, error) {
+	return b.llm.Call(ctx, prompt, callOptions(b.cfg, opts)...)
+}
+
+func (b *openAIBackend) Stream(ctx context.Context, prompt string, opts CallOptions, chunkCh chan<- string) error {
+	streamOpts := append(callOptions(b.cfg, opts), streamingOption(chunkCh))
+	_, err := b.llm.Call(ctx, prompt, streamOpts...)
+	return err
+}
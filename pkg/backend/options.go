@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// callOptions turns a backend's configured defaults plus any per-call
+// overrides into langchaingo CallOptions.
+func callOptions(cfg ProviderConfig, opts CallOptions) []llms.CallOption {
+	temperature := cfg.Temperature
+	if opts.Temperature != 0 {
+		temperature = opts.Temperature
+	}
+	maxTokens := cfg.MaxTokens
+	if opts.MaxTokens != 0 {
+		maxTokens = opts.MaxTokens
+	}
+	topK := cfg.TopK
+	if opts.TopK != 0 {
+		topK = opts.TopK
+	}
+
+	llmOpts := []llms.CallOption{llms.WithTemperature(temperature)}
+	if maxTokens > 0 {
+		llmOpts = append(llmOpts, llms.WithMaxTokens(maxTokens))
+	}
+	if topK > 0 {
+		llmOpts = append(llmOpts, llms.WithTopK(topK))
+	}
+	if cfg.Model != "" {
+		llmOpts = append(llmOpts, llms.WithModel(cfg.Model))
+	}
+	return llmOpts
+}
+
+// streamingOption forwards every chunk from a langchaingo streaming
+// callback onto chunkCh, respecting context cancellation.
+func streamingOption(chunkCh chan<- string) llms.CallOption {
+	return llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		select {
+		case chunkCh <- string(chunk):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
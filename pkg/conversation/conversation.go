@@ -0,0 +1,29 @@
+// Package conversation holds the persisted chat history: conversations and
+// the messages exchanged within them.
+package conversation
+
+import "time"
+
+// Role identifies who authored a Message.
+type Role string
+
+const (
+	RoleUser Role = "user"
+	RoleBot  Role = "bot"
+)
+
+// Conversation is a single named chat thread.
+type Conversation struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Message is one turn within a Conversation.
+type Message struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	Role           Role      `json:"role"`
+	Content        string    `json:"content"`
+	CreatedAt      time.Time `json:"created_at"`
+}
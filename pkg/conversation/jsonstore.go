@@ -0,0 +1,189 @@
+package conversation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// jsonStore is a Store backed by a single JSON file on disk. It keeps the
+// whole dataset in memory and rewrites the file on every mutation, which is
+// plenty fast for the handful of conversations a terminal chat app keeps.
+type jsonStore struct {
+	mu   sync.Mutex
+	path string
+	data jsonData
+}
+
+type jsonData struct {
+	Conversations []Conversation `json:"conversations"`
+	Messages      []Message      `json:"messages"`
+}
+
+// idSeq guarantees unique IDs even when two records are created within the
+// same nanosecond.
+var idSeq uint64
+
+// NewJSONStore opens (or creates) a JSON-file-backed Store at path.
+func NewJSONStore(path string) (Store, error) {
+	s := &jsonStore{path: path}
+
+	raw, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		s.data = jsonData{}
+	case err != nil:
+		return nil, fmt.Errorf("conversation: read store: %w", err)
+	default:
+		if err := json.Unmarshal(raw, &s.data); err != nil {
+			return nil, fmt.Errorf("conversation: parse store: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func newID() string {
+	n := atomic.AddUint64(&idSeq, 1)
+	return strconv.FormatInt(time.Now().UnixNano(), 36) + strconv.FormatUint(n, 36)
+}
+
+func (s *jsonStore) ListConversations() ([]Conversation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Conversation, len(s.data.Conversations))
+	copy(out, s.data.Conversations)
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (s *jsonStore) CreateConversation(title string) (Conversation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := Conversation{ID: newID(), Title: title, CreatedAt: time.Now()}
+	s.data.Conversations = append(s.data.Conversations, c)
+	return c, s.save()
+}
+
+func (s *jsonStore) RenameConversation(id, title string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.data.Conversations {
+		if c.ID == id {
+			s.data.Conversations[i].Title = title
+			return s.save()
+		}
+	}
+	return fmt.Errorf("conversation: rename: no conversation with id %q", id)
+}
+
+func (s *jsonStore) DeleteConversation(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	convs := s.data.Conversations[:0]
+	found := false
+	for _, c := range s.data.Conversations {
+		if c.ID == id {
+			found = true
+			continue
+		}
+		convs = append(convs, c)
+	}
+	if !found {
+		return fmt.Errorf("conversation: delete: no conversation with id %q", id)
+	}
+	s.data.Conversations = convs
+
+	msgs := s.data.Messages[:0]
+	for _, m := range s.data.Messages {
+		if m.ConversationID != id {
+			msgs = append(msgs, m)
+		}
+	}
+	s.data.Messages = msgs
+
+	return s.save()
+}
+
+func (s *jsonStore) ListMessages(conversationID string) ([]Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Message
+	for _, m := range s.data.Messages {
+		if m.ConversationID == conversationID {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (s *jsonStore) AppendMessage(conversationID string, role Role, content string) (Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.hasConversation(conversationID) {
+		return Message{}, fmt.Errorf("conversation: append message: no conversation with id %q", conversationID)
+	}
+
+	m := Message{
+		ID:             newID(),
+		ConversationID: conversationID,
+		Role:           role,
+		Content:        content,
+		CreatedAt:      time.Now(),
+	}
+	s.data.Messages = append(s.data.Messages, m)
+	return m, s.save()
+}
+
+// hasConversation reports whether id names a conversation that still
+// exists. Callers must hold s.mu.
+func (s *jsonStore) hasConversation(id string) bool {
+	for _, c := range s.data.Conversations {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *jsonStore) UpdateMessage(id, content string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, m := range s.data.Messages {
+		if m.ID == id {
+			s.data.Messages[i].Content = content
+			return s.save()
+		}
+	}
+	return fmt.Errorf("conversation: update message: no message with id %q", id)
+}
+
+// save rewrites the backing file with the current in-memory dataset.
+func (s *jsonStore) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("conversation: create store dir: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("conversation: marshal store: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, raw, 0o644); err != nil {
+		return fmt.Errorf("conversation: write store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package conversation
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestStore(t *testing.T) Store {
+	t.Helper()
+	store, err := NewJSONStore(filepath.Join(t.TempDir(), "conversations.json"))
+	if err != nil {
+		t.Fatalf("NewJSONStore: %v", err)
+	}
+	return store
+}
+
+func TestCreateConversation(t *testing.T) {
+	store := newTestStore(t)
+
+	c, err := store.CreateConversation("Test Chat")
+	if err != nil {
+		t.Fatalf("CreateConversation: %v", err)
+	}
+	if c.ID == "" {
+		t.Fatal("CreateConversation returned an empty ID")
+	}
+	if c.Title != "Test Chat" {
+		t.Fatalf("Title = %q, want %q", c.Title, "Test Chat")
+	}
+
+	convs, err := store.ListConversations()
+	if err != nil {
+		t.Fatalf("ListConversations: %v", err)
+	}
+	if len(convs) != 1 || convs[0].ID != c.ID {
+		t.Fatalf("ListConversations = %+v, want a single entry with ID %q", convs, c.ID)
+	}
+}
+
+func TestRenameConversation(t *testing.T) {
+	store := newTestStore(t)
+	c, _ := store.CreateConversation("Old Title")
+
+	if err := store.RenameConversation(c.ID, "New Title"); err != nil {
+		t.Fatalf("RenameConversation: %v", err)
+	}
+
+	convs, _ := store.ListConversations()
+	if convs[0].Title != "New Title" {
+		t.Fatalf("Title = %q, want %q", convs[0].Title, "New Title")
+	}
+
+	if err := store.RenameConversation("missing-id", "New Title"); err == nil {
+		t.Fatal("RenameConversation on an unknown id should error")
+	}
+}
+
+func TestAppendAndListMessages(t *testing.T) {
+	store := newTestStore(t)
+	c, _ := store.CreateConversation("Test Chat")
+
+	msg, err := store.AppendMessage(c.ID, RoleUser, "hello")
+	if err != nil {
+		t.Fatalf("AppendMessage: %v", err)
+	}
+	if msg.ConversationID != c.ID {
+		t.Fatalf("ConversationID = %q, want %q", msg.ConversationID, c.ID)
+	}
+
+	msgs, err := store.ListMessages(c.ID)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Content != "hello" {
+		t.Fatalf("ListMessages = %+v, want a single \"hello\" message", msgs)
+	}
+}
+
+func TestDeleteConversationRemovesMessages(t *testing.T) {
+	store := newTestStore(t)
+	c, _ := store.CreateConversation("Test Chat")
+	store.AppendMessage(c.ID, RoleUser, "hello")
+
+	if err := store.DeleteConversation(c.ID); err != nil {
+		t.Fatalf("DeleteConversation: %v", err)
+	}
+
+	convs, _ := store.ListConversations()
+	if len(convs) != 0 {
+		t.Fatalf("ListConversations = %+v, want none after delete", convs)
+	}
+
+	msgs, err := store.ListMessages(c.ID)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("ListMessages = %+v, want none for a deleted conversation", msgs)
+	}
+
+	if err := store.DeleteConversation(c.ID); err == nil {
+		t.Fatal("DeleteConversation on an already-deleted id should error")
+	}
+}
+
+// TestAppendMessageToDeletedConversation guards against orphaned messages: a
+// message appended to a conversation id that no longer exists must be
+// rejected rather than silently written to disk with nothing left to
+// reference it.
+func TestAppendMessageToDeletedConversation(t *testing.T) {
+	store := newTestStore(t)
+	c, _ := store.CreateConversation("Test Chat")
+
+	if err := store.DeleteConversation(c.ID); err != nil {
+		t.Fatalf("DeleteConversation: %v", err)
+	}
+
+	if _, err := store.AppendMessage(c.ID, RoleUser, "orphan"); err == nil {
+		t.Fatal("AppendMessage on a deleted conversation should error, not persist an orphaned message")
+	}
+
+	msgs, err := store.ListMessages(c.ID)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("ListMessages = %+v, want none after a rejected append", msgs)
+	}
+}
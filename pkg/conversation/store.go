@@ -0,0 +1,27 @@
+package conversation
+
+// Store persists conversations and their messages. Implementations must be
+// safe for concurrent use.
+type Store interface {
+	// ListConversations returns all conversations, most recently created first.
+	ListConversations() ([]Conversation, error)
+
+	// CreateConversation creates and persists a new, empty conversation.
+	CreateConversation(title string) (Conversation, error)
+
+	// RenameConversation updates a conversation's title.
+	RenameConversation(id, title string) error
+
+	// DeleteConversation removes a conversation and all of its messages.
+	DeleteConversation(id string) error
+
+	// ListMessages returns the messages for a conversation, oldest first.
+	ListMessages(conversationID string) ([]Message, error)
+
+	// AppendMessage appends a new message to a conversation and persists it.
+	// It errors if conversationID doesn't name an existing conversation.
+	AppendMessage(conversationID string, role Role, content string) (Message, error)
+
+	// UpdateMessage overwrites the content of an existing message.
+	UpdateMessage(id, content string) error
+}
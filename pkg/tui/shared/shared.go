@@ -0,0 +1,47 @@
+// Package shared holds state, types, and helpers that are common to every
+// top-level view, so views can coordinate without importing each other.
+package shared
+
+import "fmt"
+
+// View identifies which top-level screen is currently active.
+type View int
+
+const (
+	ViewChat View = iota
+	ViewConversations
+)
+
+// State is the slice of application state that outlives any single view and
+// is threaded through the top-level model as the user switches views.
+type State struct {
+	View         View   // Which screen is currently on top
+	ActiveConvID string // The conversation the chat view is showing
+}
+
+// Values carries layout and other values every view needs but none of them
+// own, so they don't each have to recompute or duplicate it.
+type Values struct {
+	Width  int
+	Height int
+}
+
+// SwitchViewMsg asks the top-level model to change the active View.
+type SwitchViewMsg struct {
+	View View
+}
+
+// ConversationSelectedMsg asks the top-level model to make a conversation
+// active and switch to the chat view to show it.
+type ConversationSelectedMsg struct {
+	ID string
+}
+
+// WrapErr wraps err with a short operation label, for errors surfaced to the
+// user from within a view.
+func WrapErr(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
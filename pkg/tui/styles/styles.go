@@ -0,0 +1,75 @@
+// Package styles holds the lipgloss styles shared across views, built once
+// as package-level values rather than inside each render.
+package styles
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	Header = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Background(lipgloss.Color("#FF5F87")).
+		Align(lipgloss.Left).
+		Padding(0, 1).
+		Height(1)
+
+	Sidebar = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFF")).
+		Background(lipgloss.Color("#333")).
+		Align(lipgloss.Left).
+		Padding(0, 1).
+		Width(25)
+
+	SidebarItem = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFF"))
+
+	SidebarItemSelected = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Background(lipgloss.Color("#FF5F87")).
+		Bold(true)
+
+	MessageView = lipgloss.NewStyle().
+		Align(lipgloss.Left).
+		Padding(0, 1)
+
+	Input = lipgloss.NewStyle().
+		Align(lipgloss.Left)
+
+	User = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("2")).
+		Italic(true).
+		Align(lipgloss.Left)
+
+	Bot = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("5")).
+		Align(lipgloss.Left)
+
+	StatusBar = lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "#343433", Dark: "#C1C6B2"}).
+		Background(lipgloss.AdaptiveColor{Light: "#D9DCCF", Dark: "#353533"})
+
+	StatusKey = lipgloss.NewStyle().
+		Inherit(StatusBar).
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Background(lipgloss.Color("#FF5F87")).
+		Padding(0, 1).
+		MarginRight(1)
+
+	StatusText = lipgloss.NewStyle().Inherit(StatusBar)
+
+	StatusError = lipgloss.NewStyle().
+		Inherit(StatusBar).
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Background(lipgloss.Color("#FF5F5F")).
+		Bold(true)
+
+	StatusEncoding = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Padding(0, 1).
+		Background(lipgloss.Color("#A550DF")).
+		Align(lipgloss.Right)
+
+	FishCake = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Padding(0, 1).
+		Background(lipgloss.Color("#6124DF"))
+)
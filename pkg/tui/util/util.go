@@ -0,0 +1,64 @@
+// Package util holds small helpers shared across views that don't belong in
+// shared state or styling.
+package util
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Truncate shortens s to at most width runes, replacing the last kept rune
+// with an ellipsis when it had to cut anything. For a width of 1 or less it
+// cuts without an ellipsis.
+func Truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(r[:width])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// WriteTempFile writes content to a new temp file matching pattern (see
+// os.CreateTemp) and returns its path.
+func WriteTempFile(pattern, content string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(content); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// ReadAndRemove reads the file at path and deletes it.
+func ReadAndRemove(path string) (string, error) {
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// EditorCommand returns a command that opens path in the user's $EDITOR,
+// falling back to vi when it isn't set. $EDITOR is split on whitespace
+// first, so settings like "code --wait" or "emacsclient -t" work instead of
+// being treated as a single executable name.
+func EditorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	argv := strings.Fields(editor)
+	argv = append(argv, path)
+	return exec.Command(argv[0], argv[1:]...)
+}
@@ -0,0 +1,572 @@
+// Package chat implements the main chat screen: the conversation sidebar,
+// the message history, and the input box.
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mitchellh/go-wordwrap"
+
+	"github.com/severity1/kakapo/pkg/backend"
+	"github.com/severity1/kakapo/pkg/conversation"
+	"github.com/severity1/kakapo/pkg/tui/shared"
+	"github.com/severity1/kakapo/pkg/tui/styles"
+)
+
+// msgResponseChunk carries one streamed fragment of Claude's reply, tagged
+// with the generation it belongs to so a stale delivery from a generation
+// that's since been cancelled or superseded can be told apart from the
+// current one.
+type msgResponseChunk struct {
+	genID int
+	chunk string
+}
+
+// msgResponseEnd signals that Claude finished streaming a reply, tagged
+// with the generation it belongs to.
+type msgResponseEnd struct {
+	genID int
+}
+
+// msgResponseError carries an error produced while streaming a reply.
+type msgResponseError error
+
+// chatMessage is one message in the active conversation, kept alongside its
+// persisted id and unstyled content so it can be re-rendered or edited.
+type chatMessage struct {
+	id      string // Empty until persisted: the synthetic welcome message, or a bot reply still streaming
+	role    conversation.Role
+	content string
+}
+
+// Fixed sizes of the chrome around the messages viewport, used to derive
+// its height instead of hard-coding the total.
+const (
+	sidebarWidth    = 25
+	headerHeight    = 1
+	statusBarHeight = 1
+	inputHeight     = 5
+)
+
+// getFixedComponentHeight returns the vertical space taken up by everything
+// other than the messages viewport: the header, the status bar, and the
+// input box.
+func getFixedComponentHeight() int {
+	return headerHeight + statusBarHeight + inputHeight
+}
+
+// Model is the chat screen's state.
+type Model struct {
+	sidebarVP  viewport.Model
+	messagesVP viewport.Model
+	input      textarea.Model
+	spinner    spinner.Model
+
+	messages     []chatMessage // The conversation, oldest first
+	messageCache []string      // messages rendered and wrapped, one block per message
+	showRaw      bool          // Bypasses messageCache to show source markdown
+
+	store        conversation.Store
+	activeConvID string
+	backend      backend.Backend
+	backendCfg   backend.Config
+
+	generating   bool
+	genID        int // Bumped on every new/cancelled generation, so stale stream messages can be dropped
+	cancelGen    context.CancelFunc
+	chunkCh      chan string
+	doneCh       chan error
+	pendingReply string
+
+	focus           focusState
+	selectedMessage int
+	messageOffsets  []int
+	editorTarget    editorTarget
+	editorFile      string
+	lastCacheWidth  int // Width the messageCache was last built for
+
+	err error
+}
+
+// New builds the chat screen for the given conversation.
+func New(store conversation.Store, initialBackend backend.Backend, cfg backend.Config, convID string, values shared.Values) Model {
+	input := textarea.New()
+	input.Placeholder = "Send a message..."
+	input.Prompt = "┃ "
+	input.CharLimit = 2048
+	input.ShowLineNumbers = false
+	input.Focus()
+	input.KeyMap.InsertNewline.SetEnabled(true)
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = styles.Bot
+
+	m := Model{
+		sidebarVP:    viewport.New(values.Width, values.Height),
+		messagesVP:   viewport.New(values.Width, values.Height),
+		input:        input,
+		spinner:      s,
+		store:        store,
+		backend:      initialBackend,
+		backendCfg:   cfg,
+		activeConvID: convID,
+		focus:        focusInput,
+	}
+	m.Resize(values)
+	m = m.LoadConversation(convID)
+	return m
+}
+
+// LoadConversation replaces the message history with the given
+// conversation's persisted messages and makes it the active conversation.
+func (m Model) LoadConversation(convID string) Model {
+	m.stopGeneration()
+
+	m.activeConvID = convID
+	m.messages = nil
+	m.focus = focusInput
+	m.selectedMessage = 0
+
+	history, err := m.store.ListMessages(convID)
+	if err != nil {
+		m.err = shared.WrapErr("load conversation", err)
+		return m
+	}
+
+	for _, msg := range history {
+		m.messages = append(m.messages, chatMessage{id: msg.ID, role: msg.Role, content: msg.Content})
+	}
+	if len(m.messages) == 0 {
+		m.messages = []chatMessage{{role: conversation.RoleBot, content: "Claude has entered the chat"}}
+	}
+
+	m.refreshSidebar()
+	m.renderAll(m.messagesVP.Width)
+	m.messagesVP.GotoBottom()
+	return m
+}
+
+// renderMessage styles and wraps a single message to the given width. Bot
+// replies are rendered as markdown (with syntax-highlighted code fences);
+// user messages keep the plain word-wrapped style.
+func renderMessage(msg chatMessage, width int) string {
+	if msg.role == conversation.RoleUser {
+		wrapped := wordwrap.WrapString(msg.content, uint(width))
+		return styles.User.Render("You: " + wrapped)
+	}
+	header := styles.Bot.Render("Claude:")
+	return header + "\n" + renderMarkdown(msg.content, width)
+}
+
+// renderMarkdown renders content through glamour, falling back to plain
+// wrapped text if the renderer can't be built or fails.
+func renderMarkdown(content string, width int) string {
+	renderer, err := glamour.NewTermRenderer(glamour.WithAutoStyle(), glamour.WithWordWrap(width))
+	if err != nil {
+		return styles.Bot.Render(wordwrap.WrapString(content, uint(width)))
+	}
+
+	out, err := renderer.Render(content)
+	if err != nil {
+		return styles.Bot.Render(wordwrap.WrapString(content, uint(width)))
+	}
+	return strings.TrimRight(out, "\n")
+}
+
+// cachedMessage renders message i, marking it with the selection arrow when
+// it's the one selected in focusMessages.
+func (m *Model) cachedMessage(i int, width int) string {
+	rendered := renderMessage(m.messages[i], width)
+	if m.focus == focusMessages && i == m.selectedMessage {
+		rendered = "▶ " + rendered
+	}
+	return rendered
+}
+
+// renderAll rebuilds the whole messageCache and messageOffsets from
+// m.messages. Call it when the message list is replaced or the viewport is
+// resized; for a single streamed chunk, use invalidateLast instead.
+func (m *Model) renderAll(width int) {
+	m.messageCache = make([]string, len(m.messages))
+	m.messageOffsets = make([]int, len(m.messages))
+
+	offset := 0
+	for i := range m.messages {
+		rendered := m.cachedMessage(i, width)
+		m.messageCache[i] = rendered
+		m.messageOffsets[i] = offset
+		offset += strings.Count(rendered, "\n") + 1
+	}
+
+	m.lastCacheWidth = width
+	m.syncViewport()
+}
+
+// invalidateLast re-renders only the last message, which is the one mutated
+// by an incoming stream chunk, leaving the rest of the cache untouched.
+func (m *Model) invalidateLast(width int) {
+	last := len(m.messages) - 1
+	if last < 0 || last >= len(m.messageCache) {
+		m.renderAll(width)
+		return
+	}
+	m.messageCache[last] = m.cachedMessage(last, width)
+	m.syncViewport()
+}
+
+// syncViewport pushes either the rendered cache or, when showRaw is on, the
+// raw markdown source into the messages viewport.
+func (m *Model) syncViewport() {
+	if !m.showRaw {
+		m.messagesVP.SetContent(strings.Join(m.messageCache, "\n"))
+		return
+	}
+
+	raw := make([]string, len(m.messages))
+	for i, msg := range m.messages {
+		prefix := "Claude:"
+		if msg.role == conversation.RoleUser {
+			prefix = "You: "
+		}
+		raw[i] = prefix + msg.content
+	}
+	m.messagesVP.SetContent(strings.Join(raw, "\n"))
+}
+
+// refreshSidebar re-renders the conversation list, highlighting the active one.
+func (m *Model) refreshSidebar() {
+	convs, err := m.store.ListConversations()
+	if err != nil {
+		m.err = shared.WrapErr("list conversations", err)
+		return
+	}
+
+	lines := []string{"+ New Chat (ctrl+n)", "Chats (ctrl+l to manage)", ""}
+	for _, c := range convs {
+		line := c.Title
+		if c.ID == m.activeConvID {
+			line = styles.SidebarItemSelected.Render("> " + line)
+		} else {
+			line = styles.SidebarItem.Render("  " + line)
+		}
+		lines = append(lines, line)
+	}
+	m.sidebarVP.SetContent(strings.Join(lines, "\n"))
+}
+
+// Resize updates the sizes of the sidebar, messages viewport, and input.
+func (m *Model) Resize(values shared.Values) {
+	m.sidebarVP.Width = sidebarWidth
+	m.sidebarVP.Height = values.Height - headerHeight - statusBarHeight
+	m.messagesVP.Width = values.Width - sidebarWidth
+	m.messagesVP.Height = values.Height - getFixedComponentHeight()
+	m.input.SetWidth(values.Width - sidebarWidth)
+	m.input.SetHeight(inputHeight)
+}
+
+// Init returns the chat screen's initial command.
+func (m Model) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+// Update handles a Bubble Tea message and returns the updated model plus any
+// follow-up command.
+func (m Model) Update(msg tea.Msg, values shared.Values) (Model, tea.Cmd) {
+	var (
+		tiCmd tea.Cmd
+		vpCmd tea.Cmd
+		spCmd tea.Cmd
+	)
+	m.Resize(values)
+	if m.messagesVP.Width != m.lastCacheWidth {
+		m.renderAll(m.messagesVP.Width)
+	}
+
+	if m.focus == focusInput {
+		m.input, tiCmd = m.input.Update(msg)
+	}
+	m.messagesVP, vpCmd = m.messagesVP.Update(msg)
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.err = nil
+		switch msg.String() {
+		case "ctrl+c":
+			if m.generating {
+				m.stopGeneration()
+				return m, nil
+			}
+			return m, tea.Quit
+		case "ctrl+l":
+			return m, func() tea.Msg { return shared.SwitchViewMsg{View: shared.ViewConversations} }
+		case "ctrl+n":
+			return m, m.newConversation()
+		case "tab":
+			return m.toggleFocus(values), nil
+		case "ctrl+e":
+			return m.startEditor()
+		case "ctrl+r":
+			m.showRaw = !m.showRaw
+			m.syncViewport()
+			return m, nil
+		case "ctrl+b":
+			return m.cycleBackend(), nil
+		}
+
+		if m.focus == focusMessages {
+			return m.updateMessageFocus(msg, values), nil
+		}
+
+		switch msg.Type {
+		case tea.KeyEnter:
+			if m.generating {
+				return m, nil
+			}
+			return m.sendMessage(values)
+		}
+
+	case msgResponseChunk:
+		if msg.genID != m.genID {
+			return m, nil // Stale chunk from a generation that's since been cancelled or superseded
+		}
+		m.pendingReply += msg.chunk
+		m.messages[len(m.messages)-1].content = m.pendingReply
+		m.invalidateLast(m.messagesVP.Width)
+		m.messagesVP.GotoBottom()
+		return m, waitForChunk(m.genID, m.chunkCh, m.doneCh)
+
+	case msgResponseEnd:
+		if msg.genID != m.genID {
+			return m, nil // Stale end from a generation already finalized by stopGeneration/LoadConversation
+		}
+		if saved, err := m.store.AppendMessage(m.activeConvID, conversation.RoleBot, m.pendingReply); err != nil {
+			m.err = shared.WrapErr("persist message", err)
+		} else {
+			m.messages[len(m.messages)-1].id = saved.ID
+		}
+		m.generating = false
+		m.pendingReply = ""
+		m.cancelGen = nil
+		return m, nil
+
+	case msgResponseError:
+		m.messages[len(m.messages)-1].content = "Error processing your request."
+		m.invalidateLast(m.messagesVP.Width)
+		m.messagesVP.GotoBottom()
+		m.generating = false
+		m.pendingReply = ""
+		m.cancelGen = nil
+		m.err = msg
+		return m, nil
+
+	case msgEditorDone:
+		return m.applyEditorResult(values)
+
+	case msgEditorError:
+		m.err = msg
+		m.editorTarget = editorTargetNone
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.generating {
+			m.spinner, spCmd = m.spinner.Update(msg)
+		}
+	}
+
+	return m, tea.Batch(tiCmd, vpCmd, spCmd)
+}
+
+// newConversation creates a fresh conversation, makes it active, and tells
+// the top-level model to switch to it.
+func (m Model) newConversation() tea.Cmd {
+	return func() tea.Msg {
+		c, err := m.store.CreateConversation("New Chat")
+		if err != nil {
+			return msgResponseError(shared.WrapErr("create conversation", err))
+		}
+		return shared.ConversationSelectedMsg{ID: c.ID}
+	}
+}
+
+// sendMessage appends the user's input to the conversation and starts a
+// streaming generation for Claude's reply.
+func (m Model) sendMessage(values shared.Values) (Model, tea.Cmd) {
+	userInput := m.input.Value()
+	if strings.TrimSpace(userInput) == "" {
+		return m, nil
+	}
+
+	userMsg, err := m.store.AppendMessage(m.activeConvID, conversation.RoleUser, userInput)
+	if err != nil {
+		m.err = shared.WrapErr("persist message", err)
+		return m, nil
+	}
+	m.messages = append(m.messages, chatMessage{id: userMsg.ID, role: conversation.RoleUser, content: userInput})
+	m.messages = append(m.messages, chatMessage{role: conversation.RoleBot, content: ""})
+	m.renderAll(m.messagesVP.Width)
+
+	m.input.Reset()
+	m.messagesVP.GotoBottom()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelGen = cancel
+	m.chunkCh = make(chan string)
+	m.doneCh = make(chan error, 1)
+	m.generating = true
+	m.genID++
+
+	return m, tea.Batch(
+		m.spinner.Tick,
+		streamReply(ctx, m.genID, userInput, m.backend, m.chunkCh, m.doneCh),
+	)
+}
+
+// stopGeneration cancels the in-flight generation without tearing down the
+// chat screen. It persists whatever partial reply had already streamed in
+// (visible on screen up to this point) before dropping it, and bumps genID
+// so a msgResponseChunk/msgResponseEnd that was already in flight for this
+// generation is recognized as stale and ignored instead of being applied to
+// whatever conversation is active by the time it arrives.
+func (m *Model) stopGeneration() {
+	if !m.generating {
+		return
+	}
+	if m.cancelGen != nil {
+		m.cancelGen()
+	}
+
+	if last := len(m.messages) - 1; m.pendingReply != "" && last >= 0 {
+		m.messages[last].content = m.pendingReply
+		if saved, err := m.store.AppendMessage(m.activeConvID, conversation.RoleBot, m.pendingReply); err != nil {
+			m.err = shared.WrapErr("persist message", err)
+		} else {
+			m.messages[last].id = saved.ID
+		}
+	} else if last := len(m.messages) - 1; last >= 0 && m.messages[last].id == "" && m.messages[last].role == conversation.RoleBot {
+		m.messages = m.messages[:last] // Drop the empty placeholder bot message, nothing to show for it
+	}
+
+	m.generating = false
+	m.pendingReply = ""
+	m.cancelGen = nil
+	m.genID++
+
+	m.renderAll(m.messagesVP.Width)
+	m.messagesVP.GotoBottom()
+}
+
+// StopGeneration cancels any in-flight generation. It's a no-op if nothing
+// is generating, so callers outside the package (the top-level model,
+// switching away from the chat view entirely) can call it unconditionally.
+func (m *Model) StopGeneration() {
+	m.stopGeneration()
+}
+
+// cycleBackend switches to the next configured backend, so the user can
+// compare models without restarting the app.
+func (m Model) cycleBackend() Model {
+	names := backend.Names
+	next := 0
+	for i, n := range names {
+		if n == m.backend.Name() {
+			next = (i + 1) % len(names)
+			break
+		}
+	}
+
+	b, err := backend.New(names[next], m.backendCfg)
+	if err != nil {
+		m.err = shared.WrapErr("change model", err)
+		return m
+	}
+	m.backend = b
+	return m
+}
+
+// streamReply starts a generation against the active backend in the
+// background and returns a tea.Cmd yielding the first streamed message.
+// Subsequent chunks are collected by repeatedly issuing waitForChunk.
+// genID tags every message produced for this generation, so Update can tell
+// a stale delivery (from a generation since cancelled or superseded) apart
+// from the current one.
+func streamReply(ctx context.Context, genID int, input string, b backend.Backend, chunkCh chan string, doneCh chan error) tea.Cmd {
+	return func() tea.Msg {
+		go func() {
+			defer close(chunkCh)
+			doneCh <- b.Stream(ctx, input, backend.CallOptions{}, chunkCh)
+		}()
+
+		return waitForChunk(genID, chunkCh, doneCh)()
+	}
+}
+
+// waitForChunk reads the next streamed chunk (or the terminal error/end
+// state) and turns it into a Bubble Tea message tagged with genID.
+func waitForChunk(genID int, chunkCh chan string, doneCh chan error) tea.Cmd {
+	return func() tea.Msg {
+		chunk, ok := <-chunkCh
+		if !ok {
+			if err := <-doneCh; err != nil && err != context.Canceled {
+				return msgResponseError(err)
+			}
+			return msgResponseEnd{genID: genID}
+		}
+		return msgResponseChunk{genID: genID, chunk: chunk}
+	}
+}
+
+// View renders the chat screen.
+func (m Model) View(values shared.Values) string {
+	w := lipgloss.Width
+
+	sidebar := styles.Sidebar.Height(values.Height - headerHeight - statusBarHeight).Render(m.sidebarVP.View())
+	messagesView := styles.MessageView.
+		Width(values.Width - sidebarWidth).
+		Height(values.Height - getFixedComponentHeight()).
+		Render(m.messagesVP.View())
+	inputView := styles.Input.
+		Width(values.Width - sidebarWidth).
+		Height(inputHeight).
+		Render(m.input.View())
+
+	header := styles.Header.Width(values.Width).
+		Render(fmt.Sprintf("Kakapo 🦜  [%s/%s]", m.backend.Name(), m.backend.Model()))
+
+	statusMessage := "Status Message"
+	statusStyle := styles.StatusText
+	switch {
+	case m.err != nil:
+		statusMessage = m.err.Error()
+		statusStyle = styles.StatusError
+	case m.generating:
+		statusMessage = m.spinner.View() + " waiting for reply... (ctrl+c to cancel)"
+	case m.showRaw:
+		statusMessage = "RAW SOURCE (ctrl+r to render)"
+	case m.focus == focusMessages:
+		statusMessage = "MESSAGES: j/k move  ctrl+e edit  tab: back to input"
+	default:
+		statusMessage = "ctrl+b: change model"
+	}
+
+	statusKey := styles.StatusKey.Render("STATUS")
+	statusEncoding := styles.StatusEncoding.Render("UTF-8")
+	fishCake := styles.FishCake.Render("🍥 Fish Cake")
+	statusVal := statusStyle.
+		Width(values.Width - w(statusKey) - w(statusEncoding) - w(fishCake)).
+		Render(statusMessage)
+
+	statusBar := lipgloss.JoinHorizontal(lipgloss.Bottom, statusKey, statusVal, statusEncoding, fishCake)
+	chatArea := lipgloss.JoinVertical(lipgloss.Top, messagesView, inputView)
+	mainArea := lipgloss.JoinHorizontal(lipgloss.Bottom, sidebar, chatArea)
+
+	return lipgloss.JoinVertical(lipgloss.Top, header, mainArea, statusBar)
+}
@@ -0,0 +1,150 @@
+package chat
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/severity1/kakapo/pkg/tui/shared"
+	"github.com/severity1/kakapo/pkg/tui/util"
+)
+
+// focusState tracks which part of the chat screen is receiving keys.
+type focusState int
+
+const (
+	focusInput focusState = iota
+	focusMessages
+)
+
+// editorTarget tracks what a spawned $EDITOR session is editing, so its
+// result can be applied back to the right place.
+type editorTarget int
+
+const (
+	editorTargetNone editorTarget = iota
+	editorTargetInput
+	editorTargetMessage
+)
+
+// msgEditorDone signals that $EDITOR exited normally.
+type msgEditorDone struct{}
+
+// msgEditorError carries an error from spawning or running $EDITOR.
+type msgEditorError error
+
+// InputFocused reports whether the input box currently has focus, as
+// opposed to message-selection mode. main.go uses this to scope global key
+// bindings (like Esc-quits) to the cases where they won't collide with a
+// binding the chat screen handles itself.
+func (m Model) InputFocused() bool {
+	return m.focus == focusInput
+}
+
+// toggleFocus switches between editing the input and selecting a message.
+func (m Model) toggleFocus(values shared.Values) Model {
+	if m.focus == focusInput {
+		m.focus = focusMessages
+		m.selectedMessage = len(m.messages) - 1
+		m.input.Blur()
+	} else {
+		m.focus = focusInput
+		m.input.Focus()
+	}
+	m.renderAll(m.messagesVP.Width)
+	m.scrollToSelected()
+	return m
+}
+
+// updateMessageFocus handles j/k navigation and esc-to-cancel while
+// focusMessages is active.
+func (m Model) updateMessageFocus(msg tea.KeyMsg, values shared.Values) Model {
+	switch msg.String() {
+	case "j", "down":
+		if m.selectedMessage < len(m.messages)-1 {
+			m.selectedMessage++
+		}
+	case "k", "up":
+		if m.selectedMessage > 0 {
+			m.selectedMessage--
+		}
+	case "esc":
+		m.focus = focusInput
+		m.input.Focus()
+	}
+	m.renderAll(m.messagesVP.Width)
+	m.scrollToSelected()
+	return m
+}
+
+// scrollToSelected keeps the messages viewport aligned with the selected
+// message using the per-message y-offsets computed by renderAll.
+func (m *Model) scrollToSelected() {
+	if m.selectedMessage < 0 || m.selectedMessage >= len(m.messageOffsets) {
+		return
+	}
+	m.messagesVP.SetYOffset(m.messageOffsets[m.selectedMessage])
+}
+
+// startEditor opens the current input buffer (in focusInput) or the
+// selected message (in focusMessages) in $EDITOR.
+func (m Model) startEditor() (Model, tea.Cmd) {
+	var content string
+	if m.focus == focusInput {
+		content = m.input.Value()
+		m.editorTarget = editorTargetInput
+	} else {
+		if m.selectedMessage < 0 || m.selectedMessage >= len(m.messages) {
+			return m, nil
+		}
+		content = m.messages[m.selectedMessage].content
+		m.editorTarget = editorTargetMessage
+	}
+
+	path, err := util.WriteTempFile("kakapo-*.md", content)
+	if err != nil {
+		m.err = shared.WrapErr("open editor", err)
+		return m, nil
+	}
+	m.editorFile = path
+
+	cmd := util.EditorCommand(path)
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return msgEditorError(shared.WrapErr("editor", err))
+		}
+		return msgEditorDone{}
+	})
+}
+
+// applyEditorResult reads back $EDITOR's output and loads it into the input
+// buffer or the selected message, depending on what was being edited.
+func (m Model) applyEditorResult(values shared.Values) (Model, tea.Cmd) {
+	content, err := util.ReadAndRemove(m.editorFile)
+	if err != nil {
+		m.err = shared.WrapErr("read editor output", err)
+		m.editorTarget = editorTargetNone
+		return m, nil
+	}
+	content = strings.TrimRight(content, "\n")
+
+	switch m.editorTarget {
+	case editorTargetInput:
+		m.input.SetValue(content)
+
+	case editorTargetMessage:
+		if m.selectedMessage >= 0 && m.selectedMessage < len(m.messages) {
+			m.messages[m.selectedMessage].content = content
+			if id := m.messages[m.selectedMessage].id; id != "" {
+				if err := m.store.UpdateMessage(id, content); err != nil {
+					m.err = shared.WrapErr("persist edit", err)
+				}
+			}
+			m.renderAll(m.messagesVP.Width)
+		}
+	}
+
+	m.editorTarget = editorTargetNone
+	m.editorFile = ""
+	return m, nil
+}
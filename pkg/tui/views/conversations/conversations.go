@@ -0,0 +1,200 @@
+// Package conversations implements the full-screen conversation list:
+// switching, creating, renaming, and deleting conversations.
+package conversations
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/severity1/kakapo/pkg/conversation"
+	"github.com/severity1/kakapo/pkg/tui/shared"
+	"github.com/severity1/kakapo/pkg/tui/styles"
+)
+
+// Model is the conversation list screen's state.
+type Model struct {
+	vp     viewport.Model
+	store  conversation.Store
+	convs  []conversation.Conversation
+	cursor int
+
+	renaming bool
+	rename   textinput.Model
+
+	err error
+}
+
+// New builds the conversations screen.
+func New(store conversation.Store, values shared.Values) Model {
+	m := Model{
+		vp:     viewport.New(values.Width, values.Height),
+		store:  store,
+		rename: textinput.New(),
+	}
+	m.rename.Prompt = "New title: "
+	m.Refresh()
+	return m
+}
+
+// Refresh reloads the conversation list from the store.
+func (m *Model) Refresh() {
+	convs, err := m.store.ListConversations()
+	if err != nil {
+		m.err = shared.WrapErr("list conversations", err)
+		return
+	}
+	m.convs = convs
+	if m.cursor >= len(m.convs) {
+		m.cursor = len(m.convs) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// Resize updates the list viewport's size.
+func (m *Model) Resize(values shared.Values) {
+	m.vp.Width = values.Width
+	m.vp.Height = values.Height - 4
+}
+
+// Init returns the conversations screen's initial command.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles a Bubble Tea message and returns the updated model plus any
+// follow-up command.
+func (m Model) Update(msg tea.Msg, values shared.Values) (Model, tea.Cmd) {
+	m.Resize(values)
+
+	if m.renaming {
+		return m.updateRenaming(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.err = nil
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return shared.SwitchViewMsg{View: shared.ViewChat} }
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.convs)-1 {
+				m.cursor++
+			}
+		case "n":
+			_, err := m.store.CreateConversation("New Chat")
+			if err != nil {
+				m.err = shared.WrapErr("create conversation", err)
+				return m, nil
+			}
+			m.Refresh()
+			return m, nil
+		case "r":
+			if len(m.convs) == 0 {
+				return m, nil
+			}
+			m.renaming = true
+			m.rename.SetValue(m.convs[m.cursor].Title)
+			m.rename.Focus()
+			return m, textinput.Blink
+		case "d":
+			if len(m.convs) == 0 {
+				return m, nil
+			}
+			if err := m.store.DeleteConversation(m.convs[m.cursor].ID); err != nil {
+				m.err = shared.WrapErr("delete conversation", err)
+				return m, nil
+			}
+			m.Refresh()
+			return m, nil
+		case "enter":
+			if len(m.convs) == 0 {
+				return m, nil
+			}
+			id := m.convs[m.cursor].ID
+			return m, func() tea.Msg { return shared.ConversationSelectedMsg{ID: id} }
+		}
+	}
+
+	m.render()
+	return m, nil
+}
+
+// updateRenaming handles input while the rename text field is focused.
+func (m Model) updateRenaming(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyEnter:
+			title := strings.TrimSpace(m.rename.Value())
+			if title != "" {
+				if err := m.store.RenameConversation(m.convs[m.cursor].ID, title); err != nil {
+					m.err = shared.WrapErr("rename conversation", err)
+				}
+			}
+			m.renaming = false
+			m.rename.Blur()
+			m.Refresh()
+			return m, nil
+		case tea.KeyEsc:
+			m.renaming = false
+			m.rename.Blur()
+			return m, nil
+		}
+	}
+
+	m.rename, cmd = m.rename.Update(msg)
+	return m, cmd
+}
+
+// render rebuilds the list viewport's content from the current conversations
+// and cursor position.
+func (m *Model) render() {
+	if len(m.convs) == 0 {
+		m.vp.SetContent("No conversations yet. Press n to start one.")
+		return
+	}
+
+	lines := make([]string, 0, len(m.convs))
+	for i, c := range m.convs {
+		line := c.Title
+		if i == m.cursor {
+			line = styles.SidebarItemSelected.Render("> " + line)
+		} else {
+			line = styles.SidebarItem.Render("  " + line)
+		}
+		lines = append(lines, line)
+	}
+	m.vp.SetContent(strings.Join(lines, "\n"))
+}
+
+// View renders the conversations screen.
+func (m Model) View(values shared.Values) string {
+	header := styles.Header.Width(values.Width).Render("Conversations")
+
+	m.render()
+	body := styles.MessageView.Width(values.Width).Height(values.Height - 4).Render(m.vp.View())
+
+	help := "enter: open  n: new  r: rename  d: delete  esc: back"
+	footerStyle := styles.StatusText
+	switch {
+	case m.err != nil:
+		help = m.err.Error()
+		footerStyle = styles.StatusError
+	case m.renaming:
+		help = m.rename.View()
+	}
+	footer := footerStyle.Width(values.Width).Render(help)
+
+	return lipgloss.JoinVertical(lipgloss.Top, header, body, footer)
+}